@@ -2,41 +2,76 @@ package gin_error_handler
 
 import (
 	"errors"
-	"reflect"
+	"sort"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 )
 
 type ErrorHandler struct {
 	errMap          []ErrorMapping
 	defaultResponse func(context *gin.Context)
+	onError         OnErrorFunc
+	recoverPanics   bool
+	stackConfig     stackConfig
 }
 
 // GetMiddleware returns a middleware that handles errors with gin
 func (e *ErrorHandler) GetMiddleware() func(c *gin.Context) {
 	return func(context *gin.Context) {
+		context.Set(stackConfigKey, e.stackConfig)
+		if e.recoverPanics {
+			defer e.recover(context)
+		}
 		context.Next()
-		lastErr := context.Errors.Last()
-		if lastErr == nil {
-			return
+		e.handleErrors(context)
+	}
+}
+
+// recover turns a panic into a reported error carrying ErrPanic, so it flows
+// through the same mapping dispatch as any other error.
+func (e *ErrorHandler) recover(context *gin.Context) {
+	if r := recover(); r != nil {
+		_ = context.Error(newPanicError(r))
+		e.handleErrors(context)
+	}
+}
+
+// handleErrors runs the OnError hook and mapping dispatch for whatever
+// errors were reported to context, and falls back to defaultResponse if
+// nothing wrote a response.
+func (e *ErrorHandler) handleErrors(context *gin.Context) {
+	if len(context.Errors) == 0 {
+		return
+	}
+	if e.onError != nil {
+		for _, ginErr := range context.Errors {
+			e.onError(context, ginErr.Err, framesOf(ginErr.Err), e.findMapping(ginErr.Err))
 		}
-	extFor:
-		for _, errorMapping := range e.errMap {
-			for _, errToMap := range errorMapping.fromErrors {
-				if errors.Is(lastErr.Err, errToMap) ||
-					// in this case we cannot use errors.Is, because validator.ValidationErrors does not implement it
-					(reflect.TypeOf(errToMap) == reflect.TypeOf(validator.ValidationErrors{}) &&
-						reflect.TypeOf(lastErr.Err) == reflect.TypeOf(errToMap)) {
-					errorMapping.toResponseFunc(context, lastErr.Err)
-					break extFor
-				}
-			}
+	}
+	lastErr := context.Errors.Last()
+	for _, errorMapping := range e.errMap {
+		if !errorMapping.matches(lastErr.Err) {
+			continue
 		}
-		if !context.Writer.Written() {
-			e.defaultResponse(context)
+		context.Set(continueKey, false)
+		errorMapping.toResponseFunc(context, lastErr.Err)
+		if !shouldContinue(context) {
+			break
 		}
 	}
+	if !context.Writer.Written() {
+		e.defaultResponse(context)
+	}
+}
+
+// findMapping returns the first ErrorMapping that matches err, or nil.
+func (e *ErrorHandler) findMapping(err error) *ErrorMapping {
+	for i := range e.errMap {
+		if e.errMap[i].matches(err) {
+			return &e.errMap[i]
+		}
+	}
+	return nil
 }
 
 // NewErrorHandler returns a new ErrorHandler.
@@ -47,15 +82,42 @@ func NewErrorHandler(opts Options) (*ErrorHandler, error) {
 	if err != nil {
 		return nil, err
 	}
+	errMap := make([]ErrorMapping, len(opts.errMap))
+	copy(errMap, opts.errMap)
+	sort.SliceStable(errMap, func(i, j int) bool { return errMap[i].priority > errMap[j].priority })
 	return &ErrorHandler{
-		errMap:          opts.errMap,
+		errMap:          errMap,
 		defaultResponse: opts.defaultResponse,
+		onError:         opts.onError,
+		recoverPanics:   opts.recoverPanics,
+		stackConfig: stackConfig{
+			enabled: opts.captureStack,
+			skip:    opts.captureSkip,
+			depth:   opts.captureDepth,
+		},
 	}, nil
 }
 
 type ErrorMapping struct {
 	fromErrors     []error
+	matchFunc      MatchFunc
 	toResponseFunc func(ctx *gin.Context, err error)
+	priority       int
+}
+
+// matches reports whether err is handled by this mapping. When a MatchFunc
+// was set via When, it alone decides; otherwise err is compared against
+// fromErrors with errors.Is.
+func (r ErrorMapping) matches(err error) bool {
+	if r.matchFunc != nil {
+		return r.matchFunc(err)
+	}
+	for _, errToMap := range r.fromErrors {
+		if errors.Is(err, errToMap) {
+			return true
+		}
+	}
+	return false
 }
 
 // ToResponse sets the response function for the error mapping.
@@ -64,6 +126,23 @@ func (r ErrorMapping) ToResponse(response func(ctx *gin.Context, err error)) Err
 	return r
 }
 
+// When sets a MatchFunc that decides whether this mapping handles an error,
+// overriding the default errors.Is matching against the errors passed to
+// Map. Use this for error types, such as validator.ValidationErrors, that
+// don't implement Is.
+func (r ErrorMapping) When(match MatchFunc) ErrorMapping {
+	r.matchFunc = match
+	return r
+}
+
+// Priority sets the order mappings are tried in: mappings with a higher
+// priority are tried first. Mappings keep their relative registration order
+// when priorities are equal, which is the default (0).
+func (r ErrorMapping) Priority(priority int) ErrorMapping {
+	r.priority = priority
+	return r
+}
+
 // Map creates a new ErrorMapping from the given errors.
 func Map(err ...error) *ErrorMapping {
 	return &ErrorMapping{
@@ -0,0 +1,23 @@
+package gin_error_handler
+
+import "github.com/gin-gonic/gin"
+
+// continueKey is the gin context key used to signal fall-through between
+// mapping dispatch iterations.
+const continueKey = "gin_error_handler.continue"
+
+// Continue signals, from within a mapping's response func, that dispatch
+// should keep scanning lower-priority mappings after this one returns,
+// instead of stopping. It lets a high-priority mapping annotate or log an
+// error and defer the actual response to a more generic mapping further
+// down the list.
+func Continue(c *gin.Context) {
+	c.Set(continueKey, true)
+}
+
+// shouldContinue reports whether Continue was called during the response
+// func that just ran.
+func shouldContinue(c *gin.Context) bool {
+	v, ok := c.Get(continueKey)
+	return ok && v.(bool)
+}
@@ -0,0 +1,104 @@
+package gin_error_handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorMapping_Priority(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should try higher priority mappings first", func(t *testing.T) {
+		testErr := assert.AnError
+		var order []string
+
+		options := &Options{}
+		options.DefaultResponse(func(context *gin.Context) {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "default"})
+		})
+		options.ErrorMappings([]ErrorMapping{
+			Map(testErr).ToResponse(func(ctx *gin.Context, err error) {
+				order = append(order, "low")
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "low"})
+			}),
+			Map(testErr).Priority(10).ToResponse(func(ctx *gin.Context, err error) {
+				order = append(order, "high")
+				ctx.JSON(http.StatusConflict, gin.H{"error": "high"})
+			}),
+		})
+
+		eh, err := NewErrorHandler(*options)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		_ = c.Error(testErr)
+		eh.GetMiddleware()(c)
+
+		assert.Equal(t, []string{"high"}, order)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestContinue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should fall through to the next matching mapping", func(t *testing.T) {
+		testErr := assert.AnError
+		var order []string
+
+		options := &Options{}
+		options.DefaultResponse(func(context *gin.Context) {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "default"})
+		})
+		options.ErrorMappings([]ErrorMapping{
+			Map(testErr).Priority(10).ToResponse(func(ctx *gin.Context, err error) {
+				order = append(order, "annotate")
+				Continue(ctx)
+			}),
+			Map(testErr).ToResponse(func(ctx *gin.Context, err error) {
+				order = append(order, "render")
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "rendered"})
+			}),
+		})
+
+		eh, err := NewErrorHandler(*options)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		_ = c.Error(testErr)
+		eh.GetMiddleware()(c)
+
+		assert.Equal(t, []string{"annotate", "render"}, order)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should fall back to default response when every mapping continues", func(t *testing.T) {
+		testErr := assert.AnError
+
+		options := &Options{}
+		options.DefaultResponse(func(context *gin.Context) {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "default"})
+		})
+		options.ErrorMappings([]ErrorMapping{
+			Map(testErr).ToResponse(func(ctx *gin.Context, err error) {
+				Continue(ctx)
+			}),
+		})
+
+		eh, err := NewErrorHandler(*options)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		_ = c.Error(testErr)
+		eh.GetMiddleware()(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,50 @@
+package gin_error_handler
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MatchFunc reports whether an ErrorMapping should handle err. Set it on a
+// mapping with ErrorMapping.When to replace the default errors.Is matching
+// against the errors passed to Map.
+type MatchFunc func(err error) bool
+
+// MatchAs returns a MatchFunc that reports whether err can be assigned to a
+// value of type T, using errors.As. It's useful for mapping by error type
+// rather than by a specific sentinel value.
+func MatchAs[T error]() MatchFunc {
+	return func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}
+}
+
+// MatchValidator returns a MatchFunc that reports whether err is a
+// validator.ValidationErrors. That type doesn't implement Is, so it can't be
+// matched with errors.Is. Only err itself is checked; a ValidationErrors
+// wrapped inside another error does not match. Use ValidationFieldErrors
+// inside the mapping's response func to get at the individual field errors.
+func MatchValidator() MatchFunc {
+	return func(err error) bool {
+		_, ok := err.(validator.ValidationErrors)
+		return ok
+	}
+}
+
+// ValidationFieldErrors returns the individual field errors carried by err,
+// or nil if err is not a validator.ValidationErrors. It's meant to be called
+// from the response func of a mapping matched with MatchValidator, to render
+// a problem per invalid field.
+func ValidationFieldErrors(err error) []validator.FieldError {
+	verrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return nil
+	}
+	fieldErrors := make([]validator.FieldError, len(verrs))
+	for i, fe := range verrs {
+		fieldErrors[i] = fe
+	}
+	return fieldErrors
+}
@@ -0,0 +1,96 @@
+package gin_error_handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type wrappedTargetError struct {
+	msg string
+}
+
+func (e *wrappedTargetError) Error() string {
+	return e.msg
+}
+
+func TestMatchAs(t *testing.T) {
+	t.Run("should match when err can be assigned to T", func(t *testing.T) {
+		match := MatchAs[*wrappedTargetError]()
+		err := wrapTarget(&wrappedTargetError{msg: "boom"})
+		assert.True(t, match(err))
+	})
+
+	t.Run("should not match unrelated error types", func(t *testing.T) {
+		match := MatchAs[*wrappedTargetError]()
+		assert.False(t, match(errors.New("unrelated")))
+	})
+}
+
+func TestMatchValidator(t *testing.T) {
+	type payload struct {
+		Name string `validate:"required"`
+	}
+
+	validate := validator.New()
+	err := validate.Struct(payload{})
+
+	t.Run("should match validator.ValidationErrors", func(t *testing.T) {
+		assert.True(t, MatchValidator()(err))
+	})
+
+	t.Run("should not match other error types", func(t *testing.T) {
+		assert.False(t, MatchValidator()(errors.New("not a validation error")))
+	})
+}
+
+func TestValidationFieldErrors(t *testing.T) {
+	type payload struct {
+		Name string `validate:"required"`
+	}
+
+	validate := validator.New()
+	err := validate.Struct(payload{})
+
+	fieldErrors := ValidationFieldErrors(err)
+	assert.Len(t, fieldErrors, 1)
+	assert.Equal(t, "Name", fieldErrors[0].Field())
+
+	assert.Nil(t, ValidationFieldErrors(errors.New("not a validation error")))
+}
+
+func TestErrorMapping_When(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should use MatchFunc instead of fromErrors", func(t *testing.T) {
+		options := &Options{}
+		options.DefaultResponse(func(context *gin.Context) {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "default"})
+		})
+		options.ErrorMappings([]ErrorMapping{
+			Map().When(MatchAs[*wrappedTargetError]()).ToResponse(func(ctx *gin.Context, err error) {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			}),
+		})
+
+		eh, err := NewErrorHandler(*options)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		_ = c.Error(wrapTarget(&wrappedTargetError{msg: "boom"}))
+
+		eh.GetMiddleware()(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func wrapTarget(target *wrappedTargetError) error {
+	return errors.Join(target, errors.New("context"))
+}
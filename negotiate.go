@@ -0,0 +1,90 @@
+package gin_error_handler
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NegotiatedResponse dispatches to one of several response funcs, keyed by
+// MIME type, based on the request's Accept header. Default is the MIME type
+// used when the Accept header doesn't match any of Responses (including when
+// it is empty, "*/*", or absent).
+type NegotiatedResponse struct {
+	Responses map[string]func(c *gin.Context, err error)
+	Default   string
+}
+
+// dispatch renders err with the response func matching the request's Accept
+// header, falling back to Default.
+func (n NegotiatedResponse) dispatch(c *gin.Context, err error) {
+	mime := n.Default
+	if negotiated, ok := negotiateMime(c.GetHeader("Accept"), n.Responses); ok {
+		mime = negotiated
+	}
+	if fn, ok := n.Responses[mime]; ok {
+		fn(c, err)
+	}
+}
+
+// ToResponseNegotiated sets the response function for the error mapping to
+// one that renders itself in whichever MIME type the request asks for, per
+// NegotiatedResponse.
+func (r ErrorMapping) ToResponseNegotiated(n NegotiatedResponse) ErrorMapping {
+	r.toResponseFunc = n.dispatch
+	return r
+}
+
+type acceptEntry struct {
+	mime string
+	q    float64
+}
+
+// parseAccept parses an Accept header into media ranges ordered from most to
+// least preferred, per RFC 7231 section 5.3.2.
+func parseAccept(header string) []acceptEntry {
+	if header == "" {
+		return nil
+	}
+	parts := strings.Split(header, ",")
+	entries := make([]acceptEntry, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		segments := strings.Split(part, ";")
+		mime := strings.TrimSpace(segments[0])
+		q := 1.0
+		for _, seg := range segments[1:] {
+			seg = strings.TrimSpace(seg)
+			value, found := strings.CutPrefix(seg, "q=")
+			if !found {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		entries = append(entries, acceptEntry{mime: mime, q: q})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+	return entries
+}
+
+// negotiateMime picks the MIME type from available best matching the Accept
+// header. A "*/*" range in the header never selects a MIME type itself;
+// callers fall back to their own default for it, same as for no match.
+func negotiateMime(acceptHeader string, available map[string]func(c *gin.Context, err error)) (string, bool) {
+	for _, e := range parseAccept(acceptHeader) {
+		if e.mime == "*/*" {
+			continue
+		}
+		if _, ok := available[e.mime]; ok {
+			return e.mime, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,82 @@
+package gin_error_handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAccept(t *testing.T) {
+	entries := parseAccept("text/html, application/xml;q=0.9, application/json;q=0.8, */*;q=0.1")
+	assert.Equal(t, []acceptEntry{
+		{mime: "text/html", q: 1},
+		{mime: "application/xml", q: 0.9},
+		{mime: "application/json", q: 0.8},
+		{mime: "*/*", q: 0.1},
+	}, entries)
+}
+
+func TestNegotiatedResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newMapping := func() ErrorMapping {
+		return Map(assert.AnError).ToResponseNegotiated(NegotiatedResponse{
+			Default: "application/json",
+			Responses: map[string]func(c *gin.Context, err error){
+				"application/json": func(c *gin.Context, err error) {
+					c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				},
+				"application/problem+json": func(c *gin.Context, err error) {
+					c.Data(http.StatusBadRequest, "application/problem+json", []byte(`{"detail":"`+err.Error()+`"}`))
+				},
+				"text/html": func(c *gin.Context, err error) {
+					c.Data(http.StatusBadRequest, "text/html", []byte("<p>"+err.Error()+"</p>"))
+				},
+			},
+		})
+	}
+
+	run := func(accept string) *httptest.ResponseRecorder {
+		options := &Options{}
+		options.DefaultResponse(func(context *gin.Context) {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "default"})
+		})
+		options.ErrorMappings([]ErrorMapping{newMapping()})
+
+		eh, err := NewErrorHandler(*options)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		if accept != "" {
+			c.Request.Header.Set("Accept", accept)
+		}
+		_ = c.Error(assert.AnError)
+		eh.GetMiddleware()(c)
+		return w
+	}
+
+	t.Run("should render HTML when Accept prefers it", func(t *testing.T) {
+		w := run("text/html,application/json;q=0.5")
+		assert.Equal(t, "text/html", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("should render problem+json when requested", func(t *testing.T) {
+		w := run("application/problem+json")
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("should fall back to Default when Accept matches nothing", func(t *testing.T) {
+		w := run("application/xml")
+		assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("should fall back to Default when Accept is absent", func(t *testing.T) {
+		w := run("")
+		assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	})
+}
@@ -9,6 +9,11 @@ import (
 type Options struct {
 	errMap          []ErrorMapping
 	defaultResponse func(context *gin.Context)
+	onError         OnErrorFunc
+	captureStack    bool
+	captureSkip     int
+	captureDepth    int
+	recoverPanics   bool
 }
 
 func (o *Options) ErrorMappings(m []ErrorMapping) *Options {
@@ -21,6 +26,36 @@ func (o *Options) DefaultResponse(f func(context *gin.Context)) *Options {
 	return o
 }
 
+// OnError registers a hook invoked for every error reported to the gin
+// context, before the matched mapping's response func runs. Use it to
+// forward errors to a logger or error tracker without the middleware taking
+// a logging dependency.
+func (o *Options) OnError(f OnErrorFunc) *Options {
+	o.onError = f
+	return o
+}
+
+// CaptureStack enables stack trace capture for errors reported with
+// ginErrorHandler.Error. skip and depth are passed to runtime.Callers as-is:
+// skip controls how many of the innermost frames (runtime.Callers itself, the
+// package's internal capture helper, Error, ...) to discard, and depth bounds
+// how many frames are walked.
+func (o *Options) CaptureStack(skip, depth int) *Options {
+	o.captureStack = true
+	o.captureSkip = skip
+	o.captureDepth = depth
+	return o
+}
+
+// RecoverPanics makes the middleware recover panics from downstream
+// handlers, wrap the recovered value as an error carrying ErrPanic, and run
+// it through the same mapping dispatch as errors reported with c.Error. It
+// replaces gin's default recovery middleware.
+func (o *Options) RecoverPanics(enabled bool) *Options {
+	o.recoverPanics = enabled
+	return o
+}
+
 func (o *Options) validate() error {
 	if o.defaultResponse == nil {
 		return errors.New("defaultResponse is required")
@@ -0,0 +1,38 @@
+package gin_error_handler
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// ErrPanic is the sentinel a recovered panic is wrapped with, when
+// Options.RecoverPanics is enabled. Map it like any other error, e.g.
+// Map(ginErrorHandler.ErrPanic).ToResponse(...), and use errors.As to get at
+// the *PanicError for the recovered value and stack.
+var ErrPanic = errors.New("panic recovered")
+
+// PanicError carries the value recovered from a panic and the stack captured
+// at the point of recovery.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+	return ErrPanic
+}
+
+// panicStackSize bounds how much of the goroutine's stack runtime.Stack
+// captures for a recovered panic.
+const panicStackSize = 64 << 10
+
+func newPanicError(recovered any) *PanicError {
+	stack := make([]byte, panicStackSize)
+	stack = stack[:runtime.Stack(stack, false)]
+	return &PanicError{Value: recovered, Stack: stack}
+}
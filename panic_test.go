@@ -0,0 +1,70 @@
+package gin_error_handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorHandler_RecoverPanics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should recover a panic and dispatch it through ErrPanic", func(t *testing.T) {
+		var captured *PanicError
+
+		options := &Options{}
+		options.RecoverPanics(true)
+		options.DefaultResponse(func(context *gin.Context) {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "default"})
+		})
+		options.ErrorMappings([]ErrorMapping{
+			Map(ErrPanic).ToResponse(func(ctx *gin.Context, err error) {
+				_ = errors.As(err, &captured)
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "panic"})
+			}),
+		})
+
+		eh, err := NewErrorHandler(*options)
+		assert.NoError(t, err)
+
+		router := gin.New()
+		router.Use(eh.GetMiddleware())
+		router.GET("/boom", func(ctx *gin.Context) {
+			panic("kaboom")
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, `{"error":"panic"}`, w.Body.String())
+		assert.NotNil(t, captured)
+		assert.Equal(t, "kaboom", captured.Value)
+		assert.NotEmpty(t, captured.Stack)
+	})
+
+	t.Run("should not recover when disabled", func(t *testing.T) {
+		options := &Options{}
+		options.DefaultResponse(func(context *gin.Context) {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "default"})
+		})
+
+		eh, err := NewErrorHandler(*options)
+		assert.NoError(t, err)
+
+		router := gin.New()
+		router.Use(eh.GetMiddleware())
+		router.GET("/boom", func(ctx *gin.Context) {
+			panic("kaboom")
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+		assert.Panics(t, func() { router.ServeHTTP(w, req) })
+	})
+}
@@ -0,0 +1,94 @@
+// Package problem implements RFC 7807 "Problem Details for HTTP APIs"
+// response documents.
+package problem
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Details is an RFC 7807 problem details document.
+//
+// Extensions holds additional members that are serialized alongside the
+// standard fields, both when the document is marshaled to JSON and when it
+// is marshaled to XML.
+type Details struct {
+	Type       string         `json:"type,omitempty" xml:"type,omitempty"`
+	Title      string         `json:"title,omitempty" xml:"title,omitempty"`
+	Status     int            `json:"status,omitempty" xml:"status,omitempty"`
+	Detail     string         `json:"detail,omitempty" xml:"detail,omitempty"`
+	Instance   string         `json:"instance,omitempty" xml:"instance,omitempty"`
+	Extensions map[string]any `json:"-" xml:"-"`
+}
+
+// MarshalJSON flattens Extensions onto the top-level object, as required by
+// RFC 7807 section 3.2.
+func (d Details) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(d.Extensions)+5)
+	for k, v := range d.Extensions {
+		m[k] = v
+	}
+	if d.Type != "" {
+		m["type"] = d.Type
+	}
+	if d.Title != "" {
+		m["title"] = d.Title
+	}
+	if d.Status != 0 {
+		m["status"] = d.Status
+	}
+	if d.Detail != "" {
+		m["detail"] = d.Detail
+	}
+	if d.Instance != "" {
+		m["instance"] = d.Instance
+	}
+	return json.Marshal(m)
+}
+
+// MarshalXML flattens Extensions as sibling elements of the standard fields,
+// mirroring what MarshalJSON does for JSON, under a <problem> root element.
+// Extension keys are sorted for deterministic output.
+func (d Details) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "problem"}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	elem := func(name, value string) error {
+		if value == "" {
+			return nil
+		}
+		return e.EncodeElement(value, xml.StartElement{Name: xml.Name{Local: name}})
+	}
+	if err := elem("type", d.Type); err != nil {
+		return err
+	}
+	if err := elem("title", d.Title); err != nil {
+		return err
+	}
+	if d.Status != 0 {
+		if err := elem("status", strconv.Itoa(d.Status)); err != nil {
+			return err
+		}
+	}
+	if err := elem("detail", d.Detail); err != nil {
+		return err
+	}
+	if err := elem("instance", d.Instance); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(d.Extensions))
+	for k := range d.Extensions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := elem(k, fmt.Sprintf("%v", d.Extensions[k])); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
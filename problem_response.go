@@ -0,0 +1,109 @@
+package gin_error_handler
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sgallizia/gin-error-handler/problem"
+)
+
+// ProblemOption customizes a problem.Details document before it is written
+// to the response.
+type ProblemOption func(*problem.Details)
+
+// WithProblemType sets the Type URI of the problem details document.
+func WithProblemType(t string) ProblemOption {
+	return func(d *problem.Details) {
+		d.Type = t
+	}
+}
+
+// WithProblemInstance sets the Instance URI of the problem details document.
+// When not set, ProblemResponse fills it in from the request path.
+func WithProblemInstance(instance string) ProblemOption {
+	return func(d *problem.Details) {
+		d.Instance = instance
+	}
+}
+
+// WithProblemExtension adds a member to the Extensions map of the problem
+// details document.
+func WithProblemExtension(key string, value any) ProblemOption {
+	return func(d *problem.Details) {
+		if d.Extensions == nil {
+			d.Extensions = map[string]any{}
+		}
+		d.Extensions[key] = value
+	}
+}
+
+// ProblemResponse returns a response function, suitable for
+// ErrorMapping.ToResponse, that renders err as an RFC 7807 problem details
+// document. It sets Content-Type to application/problem+json, or
+// application/problem+xml when the request Accept header asks for XML. The
+// Instance field is populated from the request path, and, when present, the
+// X-Request-ID request header is copied into a requestId extension.
+func ProblemResponse(status int, title string, opts ...ProblemOption) func(c *gin.Context, err error) {
+	return func(c *gin.Context, err error) {
+		d := problem.Details{
+			Status: status,
+			Title:  title,
+			Detail: err.Error(),
+		}
+		for _, opt := range opts {
+			opt(&d)
+		}
+		if d.Instance == "" {
+			d.Instance = c.Request.URL.Path
+		}
+		if reqID := c.GetHeader("X-Request-ID"); reqID != "" {
+			if d.Extensions == nil {
+				d.Extensions = map[string]any{}
+			}
+			d.Extensions["requestId"] = reqID
+		}
+		if wantsProblemXML(c) {
+			body, marshalErr := xml.Marshal(d)
+			if marshalErr != nil {
+				c.Status(http.StatusInternalServerError)
+				return
+			}
+			c.Data(status, "application/problem+xml", body)
+			return
+		}
+		body, marshalErr := json.Marshal(d)
+		if marshalErr != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Data(status, "application/problem+json", body)
+	}
+}
+
+// wantsProblemXML reports whether the request's Accept header prefers XML
+// over JSON.
+func wantsProblemXML(c *gin.Context) bool {
+	for _, e := range parseAccept(c.GetHeader("Accept")) {
+		if strings.Contains(e.mime, "json") {
+			return false
+		}
+		if strings.Contains(e.mime, "xml") {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultProblemDetails opts the whole middleware into problem+json as the
+// default (unmapped error) response, as a shortcut for DefaultResponse.
+func (o *Options) DefaultProblemDetails(status int, title string) *Options {
+	respond := ProblemResponse(status, title)
+	o.defaultResponse = func(context *gin.Context) {
+		respond(context, errors.New(title))
+	}
+	return o
+}
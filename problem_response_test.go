@@ -0,0 +1,87 @@
+package gin_error_handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should render application/problem+json by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/widgets/1", nil)
+
+		ProblemResponse(http.StatusBadRequest, "Invalid widget")(c, assert.AnError)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		body := w.Body.String()
+		assert.Contains(t, body, `"title":"Invalid widget"`)
+		assert.Contains(t, body, `"status":400`)
+		assert.Contains(t, body, `"detail":"`+assert.AnError.Error()+`"`)
+		assert.Contains(t, body, `"instance":"/widgets/1"`)
+	})
+
+	t.Run("should render application/problem+xml when Accept asks for XML", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/widgets/1", nil)
+		c.Request.Header.Set("Accept", "application/problem+xml")
+
+		ProblemResponse(http.StatusBadRequest, "Invalid widget")(c, assert.AnError)
+
+		assert.Equal(t, "application/problem+xml", w.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(w.Body.String(), "<problem>"))
+		assert.Contains(t, w.Body.String(), "<title>Invalid widget</title>")
+	})
+
+	t.Run("should copy X-Request-ID into an extension", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/widgets/1", nil)
+		c.Request.Header.Set("X-Request-ID", "req-123")
+
+		ProblemResponse(http.StatusBadRequest, "Invalid widget")(c, assert.AnError)
+
+		assert.Contains(t, w.Body.String(), `"requestId":"req-123"`)
+	})
+
+	t.Run("should copy X-Request-ID into an extension over XML too", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/widgets/1", nil)
+		c.Request.Header.Set("Accept", "application/problem+xml")
+		c.Request.Header.Set("X-Request-ID", "req-123")
+
+		ProblemResponse(http.StatusBadRequest, "Invalid widget")(c, assert.AnError)
+
+		assert.Contains(t, w.Body.String(), "<requestId>req-123</requestId>")
+	})
+}
+
+func TestOptions_DefaultProblemDetails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	options := &Options{}
+	options.DefaultProblemDetails(http.StatusInternalServerError, "Internal Server Error")
+
+	eh, err := NewErrorHandler(*options)
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/boom", nil)
+	_ = c.Error(assert.AnError)
+
+	eh.GetMiddleware()(c)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+}
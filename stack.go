@@ -0,0 +1,94 @@
+package gin_error_handler
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Frame describes a single stack frame captured for a TrackedError.
+type Frame struct {
+	File     string
+	Line     int
+	Function string
+}
+
+// TrackedError wraps an error with the stack frames captured when it was
+// reported via Error, while Options.CaptureStack was enabled.
+type TrackedError struct {
+	err    error
+	frames []Frame
+}
+
+func (e *TrackedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *TrackedError) Unwrap() error {
+	return e.err
+}
+
+// Frames returns the stack frames captured for this error.
+func (e *TrackedError) Frames() []Frame {
+	return e.frames
+}
+
+// OnErrorFunc is invoked for every error reported to the gin context, before
+// the matched mapping's response func runs, so callers can forward errors to
+// a logger or error tracker without the middleware depending on one. matched
+// is nil when no mapping handles the error.
+type OnErrorFunc func(c *gin.Context, err error, frames []Frame, matched *ErrorMapping)
+
+// stackConfig is the stack capture configuration of the ErrorHandler handling
+// the current request. GetMiddleware stores it on the gin.Context so Error
+// can read it back without depending on shared mutable state, which keeps
+// multiple ErrorHandlers with different Options.CaptureStack settings
+// independent of one another.
+type stackConfig struct {
+	enabled bool
+	skip    int
+	depth   int
+}
+
+const stackConfigKey = "gin_error_handler.stack_config"
+
+// Error reports err on the gin context, like c.Error, capturing a stack
+// trace into a TrackedError when the ErrorHandler handling this request was
+// built with Options.CaptureStack.
+func Error(c *gin.Context, err error) *gin.Error {
+	if cfg, ok := c.Get(stackConfigKey); ok {
+		if sc := cfg.(stackConfig); sc.enabled {
+			err = &TrackedError{err: err, frames: captureFrames(sc.skip, sc.depth)}
+		}
+	}
+	return c.Error(err)
+}
+
+func captureFrames(skip, depth int) []Frame {
+	pcs := make([]uintptr, depth)
+	n := runtime.Callers(skip, pcs)
+	if n == 0 {
+		return nil
+	}
+	callersFrames := runtime.CallersFrames(pcs[:n])
+	frames := make([]Frame, 0, n)
+	for {
+		frame, more := callersFrames.Next()
+		frames = append(frames, Frame{File: frame.File, Line: frame.Line, Function: frame.Function})
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// framesOf returns the stack frames captured for err, if it wraps a
+// TrackedError, or nil otherwise.
+func framesOf(err error) []Frame {
+	var tracked *TrackedError
+	if errors.As(err, &tracked) {
+		return tracked.Frames()
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package gin_error_handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_CaptureStack(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should not capture frames when disabled", func(t *testing.T) {
+		options := &Options{}
+		options.DefaultResponse(func(context *gin.Context) {})
+		eh, err := NewErrorHandler(*options)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		eh.GetMiddleware()(c)
+		Error(c, errors.New("boom"))
+
+		assert.Nil(t, framesOf(c.Errors.Last().Err))
+	})
+
+	t.Run("should capture frames when enabled", func(t *testing.T) {
+		options := &Options{}
+		options.DefaultResponse(func(context *gin.Context) {})
+		options.CaptureStack(0, 10)
+		eh, err := NewErrorHandler(*options)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		eh.GetMiddleware()(c)
+		Error(c, errors.New("boom"))
+
+		frames := framesOf(c.Errors.Last().Err)
+		assert.NotEmpty(t, frames)
+	})
+
+	t.Run("should keep capture settings independent across ErrorHandler instances", func(t *testing.T) {
+		withStack := &Options{}
+		withStack.DefaultResponse(func(context *gin.Context) {})
+		withStack.CaptureStack(0, 10)
+		ehWithStack, err := NewErrorHandler(*withStack)
+		assert.NoError(t, err)
+
+		withoutStack := &Options{}
+		withoutStack.DefaultResponse(func(context *gin.Context) {})
+		ehWithoutStack, err := NewErrorHandler(*withoutStack)
+		assert.NoError(t, err)
+
+		wWith := httptest.NewRecorder()
+		cWith, _ := gin.CreateTestContext(wWith)
+		ehWithStack.GetMiddleware()(cWith)
+		Error(cWith, errors.New("boom"))
+
+		wWithout := httptest.NewRecorder()
+		cWithout, _ := gin.CreateTestContext(wWithout)
+		ehWithoutStack.GetMiddleware()(cWithout)
+		Error(cWithout, errors.New("boom"))
+
+		assert.NotEmpty(t, framesOf(cWith.Errors.Last().Err))
+		assert.Nil(t, framesOf(cWithout.Errors.Last().Err))
+	})
+}
+
+func TestOptions_OnError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should invoke OnError for every reported error, response driven by the last", func(t *testing.T) {
+		testErr := errors.New("test error")
+		otherErr := errors.New("other error")
+		var observed []error
+
+		options := &Options{}
+		options.DefaultResponse(func(context *gin.Context) {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "default"})
+		})
+		options.OnError(func(c *gin.Context, err error, frames []Frame, matched *ErrorMapping) {
+			observed = append(observed, err)
+		})
+		options.ErrorMappings([]ErrorMapping{
+			Map(testErr).ToResponse(func(ctx *gin.Context, err error) {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			}),
+		})
+
+		eh, err := NewErrorHandler(*options)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		_ = c.Error(otherErr)
+		_ = c.Error(testErr)
+
+		eh.GetMiddleware()(c)
+
+		assert.Equal(t, []error{otherErr, testErr}, observed)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}